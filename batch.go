@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+var (
+	batchCommand         = kingpinApp.Command("batch", "Shorten or delete many URLs/codes concurrently.")
+	batchActionArg       = batchCommand.Arg("action", "Action to perform.").Required().Enum("shorten", "delete")
+	batchFileFlag        = batchCommand.Flag("file", "File to read input from, one per line or as JSON (- for stdin).").Short('f').Default("-").String()
+	batchConcurrencyFlag = batchCommand.Flag("concurrency", "Number of requests to run concurrently.").Short('c').Default("8").Int()
+	batchFormatFlag      = batchCommand.Flag("format", "Result output format.").Default("tsv").Enum("tsv", "json")
+	batchFailNoexistFlag = batchCommand.Flag("fail-no-exist", "For delete: treat a noexist code as a failed result.").Bool()
+)
+
+// batchItem is a single unit of work read from the batch input, tagged with
+// its original line number so results can be reported back in input order.
+type batchItem struct {
+	index int
+	raw   string
+}
+
+// batchResult is the outcome of processing a single batchItem.
+type batchResult struct {
+	Input string `json:"input"`
+	Ok    bool   `json:"ok"`
+	Value string `json:"value,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// batchShortenSpec is the JSON form accepted for a single `batch shorten`
+// line, for callers that need to set a code or metadata per-URL.
+type batchShortenSpec struct {
+	Url  string `json:"url"`
+	Code string `json:"code,omitempty"`
+	Meta string `json:"meta,omitempty"`
+}
+
+func batch() {
+	runBatch(*batchActionArg, *batchFileFlag, *batchConcurrencyFlag, *batchFormatFlag, *batchFailNoexistFlag)
+}
+
+// runBatch reads one item per line from path (stdin if "-"), dispatches them
+// to action concurrently across concurrency workers, prints a result per
+// item in format, and exits non-zero if any item failed. failNoexist mirrors
+// single `delete`'s --fail-no-exist: if unset, a noexist code is a benign,
+// idempotent result rather than a failure.
+func runBatch(action string, path string, concurrency int, format string, failNoexist bool) {
+	items, err := readBatchItems(path)
+	kingpin.FatalIfError(err, "error reading batch input")
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]batchResult, len(items))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for _, item := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(item batchItem) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[item.index] = runBatchItem(action, item, failNoexist)
+		}(item)
+	}
+	wg.Wait()
+
+	failed := printBatchResults(results, format)
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// readBatchItems opens path (stdin for "-") and returns one batchItem per
+// non-blank line, preserving the order items were read in.
+func readBatchItems(path string) ([]batchItem, error) {
+	var reader io.Reader
+	if path == "-" || path == "" {
+		reader = os.Stdin
+	} else {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	var items []batchItem
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		items = append(items, batchItem{index: len(items), raw: line})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func runBatchItem(action string, item batchItem, failNoexist bool) batchResult {
+	result := batchResult{Input: item.raw}
+
+	switch action {
+	case "shorten":
+		spec := batchShortenSpec{Url: item.raw}
+		if strings.HasPrefix(item.raw, "{") {
+			if err := json.Unmarshal([]byte(item.raw), &spec); err != nil {
+				result.Error = fmt.Sprintf("invalid JSON: %v", err)
+				return result
+			}
+		}
+
+		bodyStruct := &ShortenRequest{Url: spec.Url, Shortcode: spec.Code, Meta: spec.Meta}
+		respStruct := &ShortenResponse{}
+		code, err := doRequestE(http.MethodPost, ShortenEndpoint, bodyStruct, respStruct)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		if code != 200 {
+			result.Error = fmt.Sprintf("unexpected response code: %v", code)
+			return result
+		}
+		result.Ok = true
+		result.Value = respStruct.ShortUrl
+
+	case "delete":
+		code := item.raw
+		if strings.HasPrefix(code, "{") {
+			var spec DeleteRequest
+			if err := json.Unmarshal([]byte(item.raw), &spec); err != nil {
+				result.Error = fmt.Sprintf("invalid JSON: %v", err)
+				return result
+			}
+			code = spec.Code
+		}
+
+		bodyStruct := &DeleteRequest{Code: code}
+		respStruct := &DeleteResponse{}
+		respCode, err := doRequestE(http.MethodPost, DeleteEndpoint, bodyStruct, respStruct)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		if respCode != 200 {
+			result.Error = fmt.Sprintf("unexpected response code: %v", respCode)
+			return result
+		}
+		if respStruct.Status == "noexist" && failNoexist {
+			result.Error = "noexist"
+			return result
+		}
+		result.Ok = true
+		result.Value = respStruct.Status
+
+	default:
+		result.Error = fmt.Sprintf("unknown batch action: %s", action)
+	}
+
+	return result
+}
+
+// printBatchResults writes one line per result to stdout in the given
+// format and reports whether any result failed.
+func printBatchResults(results []batchResult, format string) bool {
+	failed := false
+	for _, result := range results {
+		if !result.Ok {
+			failed = true
+		}
+		switch format {
+		case "json":
+			txt, err := json.Marshal(result)
+			kingpin.FatalIfError(err, "error marshalling batch result to JSON")
+			fmt.Println(string(txt))
+		default:
+			status := "ok"
+			value := result.Value
+			if !result.Ok {
+				status = "error"
+				value = result.Error
+			}
+			fmt.Printf("%s\t%s\t%s\n", result.Input, status, value)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+var (
+	completionCommand  = kingpinApp.Command("completion", "Print a shell completion script.")
+	completionShellArg = completionCommand.Arg("shell", "Shell to generate a completion script for.").Required().Enum("bash", "zsh", "fish")
+)
+
+// fishCompletionScript is hand-rolled: kingpin only ships bash/zsh templates,
+// but its dynamic --completion-bash flag still drives the actual
+// completions, so fish just shells out to `pare --completion-bash ...`.
+const fishCompletionScript = `
+function __pare_complete
+    set -l cmd (commandline -opc)
+    set -l cur (commandline -ct)
+    pare --completion-bash $cmd $cur
+end
+complete -c pare -f -a '(__pare_complete)'
+`
+
+func completion() {
+	context, err := kingpinApp.ParseContext(nil)
+	kingpin.FatalIfError(err, "error preparing completion context")
+
+	switch *completionShellArg {
+	case "bash":
+		kingpin.FatalIfError(kingpinApp.UsageForContextWithTemplate(context, 2, kingpin.BashCompletionTemplate), "error generating completion script")
+	case "zsh":
+		kingpin.FatalIfError(kingpinApp.UsageForContextWithTemplate(context, 2, kingpin.ZshCompletionTemplate), "error generating completion script")
+	case "fish":
+		fmt.Print(fishCompletionScript)
+	}
+}
+
+// codeHints asks the server for the caller's shortcodes so `delete`/`meta`
+// can offer them as shell completions. Failures are swallowed: completion
+// should never error out a user's shell.
+func codeHints() []string {
+	resp := &ListResponse{}
+	code, err := doRequestE("GET", buildListEndpoint(""), Empty{}, resp)
+	if err != nil || code != 200 {
+		return nil
+	}
+
+	codes := make([]string, 0, len(resp.Items))
+	for _, item := range resp.Items {
+		codes = append(codes, item.Code)
+	}
+	return codes
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// ConfigFile is the on-disk shape of ~/.pare.json. Config is embedded so the
+// original flat {"APIKey":..., "Server":...} form continues to parse and
+// marshal unchanged for users who have never set up profiles.
+type ConfigFile struct {
+	Config
+	Default  string            `json:"default,omitempty"`
+	Profiles map[string]Config `json:"profiles,omitempty"`
+}
+
+var profileFlag = kingpinApp.Flag("profile", "Named server profile to use from ~/.pare.json.").Envar("PARE_PROFILE").Default("").String()
+
+func configFilePath() (string, error) {
+	usr, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(usr.HomeDir, ".pare.json"), nil
+}
+
+// loadConfigFile reads and parses ~/.pare.json, returning an empty
+// ConfigFile if it doesn't exist yet.
+func loadConfigFile() (*ConfigFile, string, error) {
+	path, err := configFilePath()
+	if err != nil {
+		return nil, "", err
+	}
+
+	file, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			debug("%s doesn't exist; ignoring.", path)
+			return &ConfigFile{}, path, nil
+		}
+		return nil, path, err
+	}
+
+	var cf ConfigFile
+	if err := json.Unmarshal(file, &cf); err != nil {
+		return nil, path, err
+	}
+	return &cf, path, nil
+}
+
+func saveConfigFile(cf *ConfigFile, path string) error {
+	txt, err := json.MarshalIndent(cf, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, txt, 0600)
+}
+
+// resolveProfile picks the active Config out of cf: an explicitly named
+// profile wins, falling back to cf.Default, falling back to the legacy flat
+// fields for files that don't use profiles at all.
+func resolveProfile(cf *ConfigFile, name string) Config {
+	if len(cf.Profiles) == 0 {
+		return cf.Config
+	}
+
+	if name == "" {
+		name = cf.Default
+	}
+	if name == "" {
+		return cf.Config
+	}
+
+	profile, ok := cf.Profiles[name]
+	if !ok {
+		kingpin.Fatalf("unknown profile: %s", name)
+	}
+	return profile
+}
+
+var (
+	configCommand = kingpinApp.Command("config", "Manage named server profiles in ~/.pare.json.")
+
+	configListCommand = configCommand.Command("list", "List configured profiles.")
+
+	configShowCommand = configCommand.Command("show", "Show the resolved configuration for a profile.")
+	configShowNameArg = configShowCommand.Arg("name", "Profile to show (defaults to the active one).").String()
+
+	configUseCommand = configCommand.Command("use", "Set the default profile.")
+	configUseNameArg = configUseCommand.Arg("name", "Profile to make the default.").Required().String()
+
+	configSetCommand = configCommand.Command("set", "Create or update a profile.")
+	configSetNameArg = configSetCommand.Arg("name", "Profile to create or update.").Required().String()
+)
+
+func configList() {
+	cf, _, err := loadConfigFile()
+	kingpin.FatalIfError(err, "error reading config")
+
+	if len(cf.Profiles) == 0 {
+		fmt.Println("(no profiles configured)")
+		return
+	}
+
+	names := make([]string, 0, len(cf.Profiles))
+	for name := range cf.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		marker := " "
+		if name == cf.Default {
+			marker = "*"
+		}
+		fmt.Printf("%s %s\t%s\n", marker, name, cf.Profiles[name].Server)
+	}
+}
+
+func configShow() {
+	cf, _, err := loadConfigFile()
+	kingpin.FatalIfError(err, "error reading config")
+
+	name := *configShowNameArg
+	if name == "" {
+		name = *profileFlag
+	}
+	resolved := resolveProfile(cf, name)
+	if *serverFlag != nil {
+		resolved.Server = (*serverFlag).String()
+	}
+	if *apiKeyFlag != "" {
+		resolved.APIKey = APIKey(*apiKeyFlag)
+	}
+
+	txt, err := json.MarshalIndent(resolved, "", "  ")
+	kingpin.FatalIfError(err, "error marshalling config")
+	fmt.Println(string(txt))
+}
+
+func configUse() {
+	cf, path, err := loadConfigFile()
+	kingpin.FatalIfError(err, "error reading config")
+
+	if _, ok := cf.Profiles[*configUseNameArg]; !ok {
+		kingpin.Fatalf("unknown profile: %s", *configUseNameArg)
+	}
+
+	cf.Default = *configUseNameArg
+	kingpin.FatalIfError(saveConfigFile(cf, path), "error writing config")
+}
+
+func configSet() {
+	cf, path, err := loadConfigFile()
+	kingpin.FatalIfError(err, "error reading config")
+
+	if cf.Profiles == nil {
+		cf.Profiles = map[string]Config{}
+	}
+
+	profile := cf.Profiles[*configSetNameArg]
+	if *serverFlag != nil {
+		profile.Server = (*serverFlag).String()
+	}
+	if *apiKeyFlag != "" {
+		profile.APIKey = APIKey(*apiKeyFlag)
+	}
+	cf.Profiles[*configSetNameArg] = profile
+
+	if cf.Default == "" {
+		cf.Default = *configSetNameArg
+	}
+
+	kingpin.FatalIfError(saveConfigFile(cf, path), "error writing config")
+}
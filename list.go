@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"text/template"
+	"time"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// ListItem is a single shortcode as returned by the listing endpoint.
+type ListItem struct {
+	Code    string       `json:"code"`
+	FullUrl string       `json:"full_url"`
+	Meta    LinkMetadata `json:"meta"`
+}
+
+// ListResponse is a page of ListEndpoint results. NextCursor is empty once
+// the final page has been reached.
+type ListResponse struct {
+	Items      []ListItem `json:"items"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+}
+
+var (
+	listCommand      = kingpinApp.Command("list", "List shortcodes, paging through the server's listing endpoint.")
+	listOwnerFlag    = listCommand.Flag("owner", "Only list codes owned by this user.").Default("").String()
+	listSinceFlag    = listCommand.Flag("since", "Only list codes created since this RFC3339 timestamp.").Default("").String()
+	listLimitFlag    = listCommand.Flag("limit", "Maximum number of items to print in total (0 for unlimited).").Default("0").Int()
+	listPageSizeFlag = listCommand.Flag("page-size", "Number of items to request per page.").Default("100").Int()
+	listSortFlag     = listCommand.Flag("sort", "Sort order to request from the server.").Default("").String()
+	listFormatFlag   = listCommand.Flag("format", "Output format.").Default("tsv").Enum("tsv", "json", "template")
+	listTemplateFlag = listCommand.Flag("template", "Go text/template to render per item; required when --format=template.").Default("").String()
+)
+
+// list pages through ListEndpoint, streaming each item to stdout as soon as
+// its page arrives rather than buffering the whole listing in memory.
+func list() {
+	tmpl := prepareListTemplate()
+
+	cursor := ""
+	printed := 0
+	for {
+		resp := &ListResponse{}
+		code := doRequest(http.MethodGet, buildListEndpoint(cursor), Empty{}, resp)
+		if code != 200 {
+			kingpin.Fatalf("unexpected response code: %v", code)
+		}
+
+		for _, item := range resp.Items {
+			if *listLimitFlag > 0 && printed >= *listLimitFlag {
+				return
+			}
+			printListItem(item, *listFormatFlag, tmpl)
+			printed++
+		}
+
+		if resp.NextCursor == "" || (*listLimitFlag > 0 && printed >= *listLimitFlag) {
+			return
+		}
+		cursor = resp.NextCursor
+	}
+}
+
+func buildListEndpoint(cursor string) string {
+	q := url.Values{}
+	if *listOwnerFlag != "" {
+		q.Set("owner", *listOwnerFlag)
+	}
+	if *listSinceFlag != "" {
+		q.Set("since", *listSinceFlag)
+	}
+	if *listSortFlag != "" {
+		q.Set("sort", *listSortFlag)
+	}
+	if *listPageSizeFlag > 0 {
+		q.Set("page_size", strconv.Itoa(*listPageSizeFlag))
+	}
+	if cursor != "" {
+		q.Set("cursor", cursor)
+	}
+
+	endpoint := ListEndpoint
+	if encoded := q.Encode(); encoded != "" {
+		endpoint += "?" + encoded
+	}
+	return endpoint
+}
+
+func prepareListTemplate() *template.Template {
+	if *listFormatFlag != "template" {
+		return nil
+	}
+	if *listTemplateFlag == "" {
+		kingpin.Fatalf("--template is required when --format=template")
+	}
+	tmpl, err := template.New("list").Parse(*listTemplateFlag)
+	kingpin.FatalIfError(err, "error parsing --template")
+	return tmpl
+}
+
+func printListItem(item ListItem, format string, tmpl *template.Template) {
+	switch format {
+	case "json":
+		txt, err := json.Marshal(item)
+		kingpin.FatalIfError(err, "error marshalling list item to JSON")
+		fmt.Println(string(txt))
+	case "template":
+		kingpin.FatalIfError(tmpl.Execute(os.Stdout, item), "error executing --template")
+		fmt.Println()
+	default:
+		fmt.Printf("%s\t%s\t%s\t%s\n", item.Code, item.FullUrl, item.Meta.Owner, item.Meta.Time.Format(time.RFC3339))
+	}
+}
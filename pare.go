@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,17 +11,16 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
-	"os/user"
-	"path/filepath"
 	"strings"
 	"time"
 )
 
-//noinspection GoUnusedConst
+// noinspection GoUnusedConst
 const (
 	ShortenEndpoint = "/api/shorten"
 	DeleteEndpoint  = "/api/delete"
 	MetaEndpoint    = "/api/meta/" // + code
+	ListEndpoint    = "/api/list"
 )
 
 type APIKey string
@@ -67,18 +67,24 @@ var (
 	serverFlag = kingpinApp.Flag("server", "Condenser server URL (overriding on-disk config).").URL()
 	apiKeyFlag = kingpinApp.Flag("apikey", "Condenser API key (overriding on-disk config).").String()
 
-	shortenCommand = kingpinApp.Command("shorten", "Shorten a URL.").Alias("short").Default()
-	shortcodeArg   = shortenCommand.Flag("code", "Code to shorten to (random if unspecified).").Default("").String()
-	metaArg        = shortenCommand.Flag("meta", "User-defined metadata.").Default("").String()
-	shortenUrlArg  = shortenCommand.Arg("url", "URL to shorten.").Required().URL()
-
-	rmCommand      = kingpinApp.Command("delete", "Delete a shortcode.").Alias("del").Alias("rm")
-	rmShortcodeArg = rmCommand.Arg("code", "Code to delete.").Required().String()
-	failNoexistArg = rmCommand.Flag("fail-no-exist", "Return non-zero exit if code didn't exist.").Bool()
+	shortenCommand        = kingpinApp.Command("shorten", "Shorten a URL.").Alias("short").Default()
+	shortcodeArg          = shortenCommand.Flag("code", "Code to shorten to (random if unspecified).").Default("").String()
+	metaArg               = shortenCommand.Flag("meta", "User-defined metadata.").Default("").String()
+	shortenFileArg        = shortenCommand.Flag("file", "Batch mode: read URLs to shorten from a file, one per line or as JSON (- for stdin).").Short('f').String()
+	shortenConcurrencyArg = shortenCommand.Flag("concurrency", "Batch mode: number of requests to run concurrently.").Short('c').Default("8").Int()
+	shortenFormatArg      = shortenCommand.Flag("format", "Batch mode: result output format.").Default("tsv").Enum("tsv", "json")
+	shortenUrlArg         = shortenCommand.Arg("url", "URL to shorten.").URL()
+
+	rmCommand        = kingpinApp.Command("delete", "Delete a shortcode.").Alias("del").Alias("rm")
+	rmFileArg        = rmCommand.Flag("file", "Batch mode: read codes to delete from a file, one per line or as JSON (- for stdin).").Short('f').String()
+	rmConcurrencyArg = rmCommand.Flag("concurrency", "Batch mode: number of requests to run concurrently.").Short('c').Default("8").Int()
+	rmFormatArg      = rmCommand.Flag("format", "Batch mode: result output format.").Default("tsv").Enum("tsv", "json")
+	rmShortcodeArg   = rmCommand.Arg("code", "Code to delete.").HintAction(codeHints).String()
+	failNoexistArg   = rmCommand.Flag("fail-no-exist", "Return non-zero exit if code didn't exist.").Bool()
 
 	metaCommand = kingpinApp.Command("meta", "Get metadata for a code.")
 	metaJsonOut = metaCommand.Flag("json", "Output JSON instead of human-readable.").Bool()
-	metaCodeArg = metaCommand.Arg("code", "Code to fetch metadata for.").Required().String()
+	metaCodeArg = metaCommand.Arg("code", "Code to fetch metadata for.").Required().HintAction(codeHints).String()
 )
 
 func main() {
@@ -92,10 +98,38 @@ func main() {
 	case metaCommand.FullCommand():
 		debug("meta: %s", *metaCodeArg)
 		meta()
+	case batchCommand.FullCommand():
+		debug("batch: %s", *batchActionArg)
+		batch()
+	case qrCommand.FullCommand():
+		debug("qr: %s", *qrCodeOrUrlArg)
+		qr()
+	case configListCommand.FullCommand():
+		configList()
+	case configShowCommand.FullCommand():
+		configShow()
+	case configUseCommand.FullCommand():
+		configUse()
+	case configSetCommand.FullCommand():
+		configSet()
+	case listCommand.FullCommand():
+		list()
+	case completionCommand.FullCommand():
+		completion()
+	case pickCommand.FullCommand():
+		pick()
 	}
 }
 
 func shorten() {
+	if *shortenFileArg != "" {
+		runBatch("shorten", *shortenFileArg, *shortenConcurrencyArg, *shortenFormatArg, false)
+		return
+	}
+	if *shortenUrlArg == nil {
+		kingpin.Fatalf("required argument 'url' not provided, or use --file to shorten in batch")
+	}
+
 	bodyStruct := &ShortenRequest{
 		Url:       (*shortenUrlArg).String(),
 		Shortcode: *shortcodeArg,
@@ -113,9 +147,21 @@ func shorten() {
 	}
 
 	fmt.Printf("%s\n", respStruct.ShortUrl)
+
+	if *qrFlag {
+		renderQr(respStruct.ShortUrl, *qrFormatFlag, *qrOutFlag, *qrEccFlag, *qrSizeFlag)
+	}
 }
 
 func rm() {
+	if *rmFileArg != "" {
+		runBatch("delete", *rmFileArg, *rmConcurrencyArg, *rmFormatArg, *failNoexistArg)
+		return
+	}
+	if *rmShortcodeArg == "" {
+		kingpin.Fatalf("required argument 'code' not provided, or use --file to delete in batch")
+	}
+
 	bodyStruct := &DeleteRequest{
 		Code: *rmShortcodeArg,
 	}
@@ -160,61 +206,111 @@ func meta() {
 	}
 }
 
+// doRequest is the fatal-on-error convenience wrapper used by one-shot
+// commands (shorten/delete/meta) where a hard failure should end the
+// process. Callers that need to keep running after a failed request (batch,
+// pick, completion) should call doRequestE directly instead.
 func doRequest(method string, endpoint string, body interface{}, response interface{}) int {
+	code, err := doRequestE(method, endpoint, body, response)
+	kingpin.FatalIfError(err, "error executing request to condenser server")
+	return code
+}
+
+// doRequestE is doRequest without the fatal exit: transport and decode
+// failures are returned to the caller instead of terminating the process.
+func doRequestE(method string, endpoint string, body interface{}, response interface{}) (int, error) {
 	config := serverDetails()
 	debug("config: %+v", *config)
 
 	txBody, err := json.Marshal(body)
-	kingpin.FatalIfError(err, "error creating shorten POST json")
+	if err != nil {
+		return 0, fmt.Errorf("error creating request json: %w", err)
+	}
 	debug("txBody: %v", string(txBody))
-	req := makeRequest(config, method, endpoint, bytes.NewReader(txBody))
-	debug("req: %#v", req)
 
-	client := http.DefaultClient
-	resp, err := client.Do(req)
-	kingpin.FatalIfError(err, "error executing POST to condenser server")
+	client := &http.Client{Timeout: *timeoutFlag}
+	deadline := time.Now().Add(*retryMaxElapsedFlag)
+
+	var resp *http.Response
+	var cancel context.CancelFunc
+	for attempt := 1; ; attempt++ {
+		req := makeRequest(config, method, endpoint, bytes.NewReader(txBody))
+		ctx, attemptCancel := context.WithTimeout(rootContext, *timeoutFlag)
+		req = req.WithContext(ctx)
+		debug("req attempt %d: %#v", attempt, req)
+
+		resp, err = client.Do(req)
+
+		retryable := false
+		delay := time.Duration(0)
+		if err != nil {
+			attemptCancel()
+			if rootContext.Err() != nil {
+				kingpin.Fatalf("aborted: %v", rootContext.Err())
+			}
+			retryable = true
+			delay = nextBackoff(attempt)
+		} else if isRetryableStatus(resp.StatusCode) {
+			resp.Body.Close()
+			attemptCancel()
+			retryable = true
+			if afterDelay, ok := retryAfterDelay(resp); ok {
+				delay = afterDelay
+			} else {
+				delay = nextBackoff(attempt)
+			}
+		}
+
+		if !retryable || attempt >= *retriesFlag || time.Now().Add(delay).After(deadline) {
+			// This is the attempt we're keeping: leave its context alive
+			// until the body below has been fully read and closed, rather
+			// than cancelling it the moment Do() returns.
+			cancel = attemptCancel
+			break
+		}
+		debug("attempt %d failed, retrying in %v", attempt, delay)
+		select {
+		case <-time.After(delay):
+		case <-rootContext.Done():
+			kingpin.Fatalf("aborted: %v", rootContext.Err())
+		}
+	}
+	if err != nil {
+		cancel()
+		return 0, fmt.Errorf("error executing request to condenser server: %w", err)
+	}
+	defer cancel()
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return resp.StatusCode
+		return resp.StatusCode, nil
 	}
 
 	rxBody, err := ioutil.ReadAll(resp.Body)
-	kingpin.FatalIfError(err, "error reading response")
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("error reading response: %w", err)
+	}
 	debug("rxBody: %v", string(rxBody))
-	err = json.Unmarshal(rxBody, &response)
-	kingpin.FatalIfError(err, "error parsing response")
+	if err := json.Unmarshal(rxBody, &response); err != nil {
+		return resp.StatusCode, fmt.Errorf("error parsing response: %w", err)
+	}
 
-	return resp.StatusCode
+	return resp.StatusCode, nil
 }
 
 func serverDetails() *Config {
-	var retUrl string
-	var apikey APIKey
-	usr, err := user.Current()
-	kingpin.FatalIfError(err, "unable to get current user HOME")
-	file, err := ioutil.ReadFile(filepath.Join(usr.HomeDir, ".pare.json"))
-	if err != nil {
-		if os.IsNotExist(err) {
-			debug("~/.pare.json doesn't exist; ignoring.")
-		} else {
-			kingpin.FatalIfError(err, "error opening ~/.pare.json")
-		}
-	} else {
-		var confJson Config
-		err := json.Unmarshal(file, &confJson)
-		kingpin.FatalIfError(err, "error parsing ~/.pare.json")
-		retUrl = confJson.Server
-		apikey = confJson.APIKey
-	}
+	cf, path, err := loadConfigFile()
+	kingpin.FatalIfError(err, "error opening "+path)
+
+	resolved := resolveProfile(cf, *profileFlag)
 
 	if *serverFlag != nil {
-		retUrl = (*serverFlag).String()
+		resolved.Server = (*serverFlag).String()
 	}
 	if *apiKeyFlag != "" {
-		apikey = APIKey(*apiKeyFlag)
+		resolved.APIKey = APIKey(*apiKeyFlag)
 	}
-	return &Config{APIKey: apikey, Server: retUrl}
+	return &resolved
 }
 
 func makeRequest(conf *Config, method string, endpoint string, body io.Reader) *http.Request {
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+var pickCommand = kingpinApp.Command("pick", "Interactively browse, search and act on your shortcodes.")
+
+// pickModel is the bubbletea model backing `pare pick`: a fuzzy-filterable
+// list of shortcodes with single-key actions on the highlighted entry.
+type pickModel struct {
+	items  []ListItem
+	cursor int
+	filter string
+	status string
+}
+
+func pick() {
+	items := fetchAllCodes()
+	if len(items) == 0 {
+		fmt.Println("no shortcodes found")
+		return
+	}
+
+	program := tea.NewProgram(&pickModel{items: items})
+	if _, err := program.Run(); err != nil {
+		kingpin.Fatalf("error running picker: %v", err)
+	}
+}
+
+// fetchAllCodes pages through ListEndpoint and collects every item. Unlike
+// `pare list`, pick needs the whole set up front to filter interactively.
+func fetchAllCodes() []ListItem {
+	var items []ListItem
+	cursor := ""
+	for {
+		resp := &ListResponse{}
+		code := doRequest(http.MethodGet, buildListEndpoint(cursor), Empty{}, resp)
+		if code != 200 {
+			kingpin.Fatalf("unexpected response code: %v", code)
+		}
+		items = append(items, resp.Items...)
+		if resp.NextCursor == "" {
+			return items
+		}
+		cursor = resp.NextCursor
+	}
+}
+
+func (m *pickModel) Init() tea.Cmd {
+	return nil
+}
+
+// visible returns the items matching the current filter, most recently
+// typed filter applied as a fuzzy (in-order subsequence) match.
+func (m *pickModel) visible() []ListItem {
+	if m.filter == "" {
+		return m.items
+	}
+	var out []ListItem
+	for _, item := range m.items {
+		if fuzzyMatch(m.filter, item.Code) || fuzzyMatch(m.filter, item.FullUrl) {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func fuzzyMatch(pattern string, text string) bool {
+	pattern = strings.ToLower(pattern)
+	text = strings.ToLower(text)
+	pos := 0
+	for _, r := range pattern {
+		idx := strings.IndexRune(text[pos:], r)
+		if idx < 0 {
+			return false
+		}
+		pos += idx + len(string(r))
+	}
+	return true
+}
+
+func (m *pickModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	visible := m.visible()
+
+	switch keyMsg.Type {
+	case tea.KeyCtrlC, tea.KeyEsc:
+		return m, tea.Quit
+	case tea.KeyUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, nil
+	case tea.KeyDown:
+		if m.cursor < len(visible)-1 {
+			m.cursor++
+		}
+		return m, nil
+	case tea.KeyBackspace:
+		if m.filter != "" {
+			m.filter = m.filter[:len(m.filter)-1]
+			m.cursor = 0
+		}
+		return m, nil
+	case tea.KeyRunes:
+		switch string(keyMsg.Runes) {
+		case "q":
+			return m, tea.Quit
+		case "c":
+			m.copySelected(visible)
+			return m, nil
+		case "d":
+			m.deleteSelected(visible)
+			return m, nil
+		case "v":
+			m.viewSelected(visible)
+			return m, nil
+		case "r":
+			m.qrSelected(visible)
+			return m, nil
+		default:
+			m.filter += string(keyMsg.Runes)
+			m.cursor = 0
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+func (m *pickModel) selected(visible []ListItem) (ListItem, bool) {
+	if m.cursor < 0 || m.cursor >= len(visible) {
+		return ListItem{}, false
+	}
+	return visible[m.cursor], true
+}
+
+func (m *pickModel) copySelected(visible []ListItem) {
+	item, ok := m.selected(visible)
+	if !ok {
+		return
+	}
+	shortUrl := shortUrlForCode(item.Code)
+	if err := clipboard.WriteAll(shortUrl); err != nil {
+		m.status = fmt.Sprintf("error copying to clipboard: %v", err)
+		return
+	}
+	m.status = fmt.Sprintf("copied %s to clipboard", shortUrl)
+}
+
+func (m *pickModel) deleteSelected(visible []ListItem) {
+	item, ok := m.selected(visible)
+	if !ok {
+		return
+	}
+	respStruct := &DeleteResponse{}
+	code, err := doRequestE(http.MethodPost, DeleteEndpoint, &DeleteRequest{Code: item.Code}, respStruct)
+	if err != nil {
+		m.status = fmt.Sprintf("error deleting %s: %v", item.Code, err)
+		return
+	}
+	if code != 200 {
+		m.status = fmt.Sprintf("error deleting %s: unexpected response code %v", item.Code, code)
+		return
+	}
+	m.status = fmt.Sprintf("deleted %s/%s", respStruct.Code, respStruct.Status)
+	for i, existing := range m.items {
+		if existing.Code == item.Code {
+			m.items = append(m.items[:i], m.items[i+1:]...)
+			break
+		}
+	}
+	if m.cursor >= len(m.visible()) {
+		m.cursor = len(m.visible()) - 1
+	}
+}
+
+func (m *pickModel) viewSelected(visible []ListItem) {
+	item, ok := m.selected(visible)
+	if !ok {
+		return
+	}
+	respStruct := &MetaResponse{}
+	code, err := doRequestE(http.MethodGet, MetaEndpoint+item.Code, Empty{}, respStruct)
+	if err != nil {
+		m.status = fmt.Sprintf("error fetching metadata for %s: %v", item.Code, err)
+		return
+	}
+	if code != 200 {
+		m.status = fmt.Sprintf("error fetching metadata for %s: unexpected response code %v", item.Code, code)
+		return
+	}
+	m.status = fmt.Sprintf("%s -> %s (owner: %s, created: %s)", item.Code, respStruct.FullUrl,
+		respStruct.Meta.Owner, respStruct.Meta.Time.Format(time.RFC3339))
+}
+
+func (m *pickModel) qrSelected(visible []ListItem) {
+	item, ok := m.selected(visible)
+	if !ok {
+		return
+	}
+	shortUrl := shortUrlForCode(item.Code)
+	code, err := qrcodeNew(shortUrl)
+	if err != nil {
+		m.status = fmt.Sprintf("error rendering QR for %s: %v", item.Code, err)
+		return
+	}
+	m.status = code.ToSmallString(false)
+}
+
+func (m *pickModel) View() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "pare pick - filter: %s\n\n", m.filter)
+
+	visible := m.visible()
+	for i, item := range visible {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "> "
+		}
+		fmt.Fprintf(&b, "%s%s\t%s\n", cursor, item.Code, item.FullUrl)
+	}
+
+	b.WriteString("\n[type to filter] [up/down] [c]opy [d]elete [v]iew [r]egenerate qr [q]uit\n")
+	if m.status != "" {
+		fmt.Fprintf(&b, "\n%s\n", m.status)
+	}
+	return b.String()
+}
+
+// shortUrlForCode builds the redirect URL for a shortcode against the
+// currently configured server, the same convention `pare qr` uses.
+func shortUrlForCode(code string) string {
+	config := serverDetails()
+	return strings.TrimRight(config.Server, "/") + "/" + code
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+var (
+	qrFlag       = shortenCommand.Flag("qr", "Also render the shortened URL as a QR code.").Bool()
+	qrFormatFlag = shortenCommand.Flag("qr-format", "QR code format to use with --qr (ansi, utf8, png or svg).").Default("ansi").Enum("ansi", "utf8", "png", "svg")
+	qrOutFlag    = shortenCommand.Flag("qr-out", "Write the QR code to this file instead of the terminal.").Default("").String()
+	qrEccFlag    = shortenCommand.Flag("qr-ecc", "QR code error-correction level (L, M, Q or H).").Default("M").Enum("L", "M", "Q", "H")
+	qrSizeFlag   = shortenCommand.Flag("qr-size", "Module scale for png/svg output.").Default("8").Int()
+
+	qrCommand      = kingpinApp.Command("qr", "Render a QR code for a shortcode or URL.")
+	qrCodeOrUrlArg = qrCommand.Arg("code-or-url", "Shortcode or full URL to encode.").Required().String()
+	qrFormatArg    = qrCommand.Flag("format", "QR code output format.").Default("ansi").Enum("ansi", "utf8", "png", "svg")
+	qrCommandOut   = qrCommand.Flag("qr-out", "Write the QR code to this file instead of the terminal.").Default("").String()
+	qrCommandEcc   = qrCommand.Flag("qr-ecc", "QR code error-correction level (L, M, Q or H).").Default("M").Enum("L", "M", "Q", "H")
+	qrCommandSize  = qrCommand.Flag("qr-size", "Module scale for png/svg output.").Default("8").Int()
+)
+
+func qr() {
+	content := *qrCodeOrUrlArg
+	if !strings.Contains(content, "://") {
+		content = shortUrlForCode(content)
+	}
+	renderQr(content, *qrFormatArg, *qrCommandOut, *qrCommandEcc, *qrCommandSize)
+}
+
+// renderQr encodes content as a QR code in the given format (ansi, utf8, png
+// or svg) and writes it to outPath, or the terminal if outPath is empty.
+func renderQr(content string, format string, outPath string, ecc string, size int) {
+	level, err := parseQrEcc(ecc)
+	kingpin.FatalIfError(err, "invalid QR error-correction level")
+
+	code, err := qrcode.New(content, level)
+	kingpin.FatalIfError(err, "error generating QR code")
+
+	switch format {
+	case "ansi":
+		writeQrText(outPath, code.ToString(false))
+	case "utf8":
+		writeQrText(outPath, code.ToSmallString(false))
+	case "png":
+		png, err := code.PNG(-size)
+		kingpin.FatalIfError(err, "error rendering QR code to PNG")
+		writeQrBytes(outPath, png)
+	case "svg":
+		writeQrText(outPath, qrToSvg(code, size))
+	default:
+		kingpin.Fatalf("unknown QR format: %s", format)
+	}
+}
+
+// qrcodeNew builds a QR code for content at the default (Medium)
+// error-correction level, for callers that just want to render it in place.
+func qrcodeNew(content string) (*qrcode.QRCode, error) {
+	return qrcode.New(content, qrcode.Medium)
+}
+
+func parseQrEcc(ecc string) (qrcode.RecoveryLevel, error) {
+	switch strings.ToUpper(ecc) {
+	case "L":
+		return qrcode.Low, nil
+	case "M", "":
+		return qrcode.Medium, nil
+	case "Q":
+		return qrcode.High, nil
+	case "H":
+		return qrcode.Highest, nil
+	default:
+		return qrcode.Medium, fmt.Errorf("unknown QR ECC level: %s", ecc)
+	}
+}
+
+// qrToSvg renders a QR code's module matrix as a minimal SVG document, with
+// each module drawn moduleSize units square.
+func qrToSvg(code *qrcode.QRCode, moduleSize int) string {
+	if moduleSize <= 0 {
+		moduleSize = 8
+	}
+	bitmap := code.Bitmap()
+	dim := len(bitmap) * moduleSize
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d">`, dim, dim)
+	b.WriteString(`<rect width="100%" height="100%" fill="#ffffff"/>`)
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="#000000"/>`,
+				x*moduleSize, y*moduleSize, moduleSize, moduleSize)
+		}
+	}
+	b.WriteString(`</svg>`)
+	return b.String()
+}
+
+func writeQrText(outPath string, text string) {
+	if outPath == "" {
+		fmt.Println(text)
+		return
+	}
+	err := ioutil.WriteFile(outPath, []byte(text), 0644)
+	kingpin.FatalIfError(err, "error writing QR code")
+}
+
+func writeQrBytes(outPath string, data []byte) {
+	if outPath == "" {
+		kingpin.Fatalf("--qr-out is required for binary QR formats (png)")
+	}
+	err := ioutil.WriteFile(outPath, data, 0644)
+	kingpin.FatalIfError(err, "error writing QR code")
+}
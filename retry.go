@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+)
+
+var (
+	timeoutFlag         = kingpinApp.Flag("timeout", "Per-attempt request timeout.").Default("10s").Duration()
+	retriesFlag         = kingpinApp.Flag("retries", "Maximum number of attempts per request.").Default("5").Int()
+	retryMaxElapsedFlag = kingpinApp.Flag("retry-max-elapsed", "Stop retrying a request once this much time has elapsed since its first attempt.").Default("30s").Duration()
+)
+
+const (
+	retryInitialBackoff = 500 * time.Millisecond
+	retryMaxBackoff     = 30 * time.Second
+)
+
+// rootContext is cancelled on SIGINT so an in-flight request or retry wait
+// aborts promptly instead of running until process exit.
+var rootContext, cancelRootContext = context.WithCancel(context.Background())
+
+func init() {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	go func() {
+		<-sigCh
+		cancelRootContext()
+	}()
+}
+
+// isRetryableStatus reports whether an HTTP response with this status code
+// is worth retrying. 4xx other than 429 are treated as permanent failures.
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// retryAfterDelay parses a Retry-After header (either delay-seconds or an
+// HTTP-date), returning the delay it specifies and whether one was present.
+func retryAfterDelay(resp *http.Response) (time.Duration, bool) {
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		if delay := time.Until(when); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// nextBackoff returns the jittered delay before the given attempt (1-indexed),
+// doubling retryInitialBackoff each attempt up to retryMaxBackoff.
+func nextBackoff(attempt int) time.Duration {
+	backoff := retryInitialBackoff
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+		if backoff >= retryMaxBackoff {
+			backoff = retryMaxBackoff
+			break
+		}
+	}
+	return time.Duration(rand.Int63n(int64(backoff)))
+}